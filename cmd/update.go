@@ -0,0 +1,215 @@
+// Copyright © 2017 Microsoft <wastore@microsoft.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sannya-singal/azure-storage-azcopy/common"
+	"github.com/sannya-singal/azure-storage-azcopy/updater"
+)
+
+const updateChannelEnvVar = "AZCOPY_UPDATE_CHANNEL"
+
+var updateSnoozeFlag time.Duration
+var updateChannelFlag string
+var updateJSONFlag bool
+var forceFlag bool
+
+func init() {
+	rootCmd.AddCommand(updateCmd)
+	updateCmd.PersistentFlags().DurationVar(&updateSnoozeFlag, "snooze", 0,
+		"silence update banners for the given duration (e.g. 72h), without affecting the reported status")
+	updateCmd.Flags().BoolVar(&updateJSONFlag, "json", false,
+		"print the update status as JSON instead of text")
+	rootCmd.PersistentFlags().StringVar(&updateChannelFlag, "update-channel", "",
+		"which release channel to check for updates against: stable, preview or nightly (default stable; can also be set via "+updateChannelEnvVar+")")
+	rootCmd.PersistentFlags().BoolVar(&forceFlag, "force", false,
+		"start the job even if this build's update status is Incompatible")
+
+	wireUpdateLifecycleHooks()
+}
+
+// wireUpdateLifecycleHooks hooks the update checker into every azcopy invocation: a background
+// poll and an Incompatible-build refusal before a subcommand runs, and the nag banner after one
+// finishes. The refusal and the banner are skipped for updateCmd itself (the poll still runs), since `azcopy update` is exactly how a
+// user on an Incompatible build is expected to inspect and resolve that status, and it already
+// prints the same information as its own output. Existing hooks, if any, are chained rather than
+// replaced so this doesn't clobber whatever root.go itself uses PersistentPreRunE/PostRunE for.
+func wireUpdateLifecycleHooks() {
+	previousPreRun := rootCmd.PersistentPreRunE
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		newUpdateChecker().StartBackgroundPoll(context.Background(), updater.DefaultPollInterval)
+
+		if cmd != updateCmd {
+			if err := RefuseIncompatibleJob(); err != nil {
+				return err
+			}
+		}
+
+		if previousPreRun != nil {
+			return previousPreRun(cmd, args)
+		}
+		return nil
+	}
+
+	previousPostRun := rootCmd.PersistentPostRunE
+	rootCmd.PersistentPostRunE = func(cmd *cobra.Command, args []string) error {
+		if cmd != updateCmd {
+			PrintUpdateBannerIfDue()
+		}
+
+		if previousPostRun != nil {
+			return previousPostRun(cmd, args)
+		}
+		return nil
+	}
+}
+
+// resolveUpdateChannel determines the active update channel: the --update-channel flag takes
+// precedence, then the AZCOPY_UPDATE_CHANNEL environment variable, defaulting to stable.
+func resolveUpdateChannel() updater.Channel {
+	raw := updateChannelFlag
+	if raw == "" {
+		raw = os.Getenv(updateChannelEnvVar)
+	}
+
+	channel, err := updater.ParseChannel(raw)
+	if err != nil {
+		fmt.Println(err)
+		return updater.ChannelStable
+	}
+	return channel
+}
+
+var updateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Check azcopy's update status against the latest published release.",
+	Long: `Check azcopy's update status against the latest published release.
+
+Reports the current status (UpToDate, UpdateAvailable, Deprecated or Incompatible), when azcopy
+last checked, and a link to release notes when one is known. Use --snooze to silence the
+end-of-job banner for a while without changing the reported status.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		checker := newUpdateChecker()
+
+		if updateSnoozeFlag > 0 {
+			if err := checker.Snooze(updateSnoozeFlag); err != nil {
+				return fmt.Errorf("failed to snooze update notifications: %w", err)
+			}
+			fmt.Printf("Update notifications snoozed for %s.\n", updateSnoozeFlag)
+			return nil
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		status, err := checker.Check(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to check for updates: %w", err)
+		}
+
+		if updateJSONFlag {
+			report := updateStatusReport{
+				Channel:         resolveUpdateChannel().String(),
+				Status:          status,
+				LastChecked:     checker.LastChecked(),
+				ReleaseNotesURL: checker.ReleaseNotesURL(),
+			}
+			data, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal update status: %w", err)
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+
+		fmt.Printf("Update channel: %s\n", resolveUpdateChannel())
+		fmt.Printf("Current status: %s\n", status)
+		if last := checker.LastChecked(); !last.IsZero() {
+			fmt.Printf("Last checked:   %s\n", last.Format(time.RFC1123))
+		}
+		if notes := checker.ReleaseNotesURL(); notes != "" {
+			fmt.Printf("Release notes:  %s\n", notes)
+		}
+
+		return nil
+	},
+}
+
+// updateStatusReport is the JSON shape printed by `azcopy update --json`; its status field is
+// written by name through UpdateStatus's MarshalJSON, so scripts and CI can detect a Deprecated
+// or Incompatible build.
+type updateStatusReport struct {
+	Channel         string               `json:"channel"`
+	Status          updater.UpdateStatus `json:"status"`
+	LastChecked     time.Time            `json:"lastChecked"`
+	ReleaseNotesURL string               `json:"releaseNotesUrl,omitempty"`
+}
+
+// newUpdateChecker builds the UpdateChecker shared by the update command, the end-of-job
+// banner, and the incompatible-build guard, all keyed off the same on-disk cache file.
+func newUpdateChecker() *updater.UpdateChecker {
+	current, err := NewVersion(common.AZCopyVersion)
+	if err != nil {
+		// the running build's own version string should always be valid; fall back to a
+		// version that looks maximally out of date rather than panicking
+		current, _ = NewVersion("0.0.0")
+	}
+	return updater.NewUpdateChecker(*current, resolveUpdateChannel(), updateCacheFilePath())
+}
+
+// updateCacheFilePath returns the on-disk location of the update-checker cache, reusing the
+// same plan-file directory azcopy already uses for job logs and resume state.
+func updateCacheFilePath() string {
+	dir, err := os.UserHomeDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, ".azcopy", "latest_version_cache.json")
+}
+
+// PrintUpdateBannerIfDue prints the one-shot update banner at the end of a command's output, if
+// one is due per the on-disk cache's 24h-per-severity rate limit. Wired into every subcommand but
+// update itself via wireUpdateLifecycleHooks.
+func PrintUpdateBannerIfDue() {
+	if msg, ok := newUpdateChecker().Banner(); ok {
+		fmt.Println(msg)
+	}
+}
+
+// RefuseIncompatibleJob returns an error if the running build is Incompatible and --force was
+// not passed, so that job-starting commands refuse to proceed. It is wired into every subcommand
+// but update itself via wireUpdateLifecycleHooks.
+func RefuseIncompatibleJob() error {
+	if newUpdateChecker().Status() == updater.Incompatible && !forceFlag {
+		return fmt.Errorf("this version of azcopy is no longer supported; upgrade (see 'azcopy update') or pass --force to continue anyway")
+	}
+	return nil
+}
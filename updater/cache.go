@@ -0,0 +1,111 @@
+// Copyright © 2017 Microsoft <wastore@microsoft.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package updater
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+)
+
+// checkNagWindow is how long a banner, once shown for a given severity, is suppressed for.
+const checkNagWindow = 24 * time.Hour
+
+// cacheEntry is the on-disk record of the last update check: what we last learned from the
+// manifest, when we learned it, when we last nagged the user about it, and any snooze the user
+// asked for via `azcopy update --snooze`.
+type cacheEntry struct {
+	LatestStableVersion  string       `json:"latestStableVersion"`
+	LatestPreviewVersion string       `json:"latestPreviewVersion"`
+	LatestNightlyVersion string       `json:"latestNightlyVersion"`
+	MinimumVersion       string       `json:"minimumVersion"`
+	ReleaseNotesURL      string       `json:"releaseNotesUrl"`
+	Channel              string       `json:"channel"`
+	Status               UpdateStatus `json:"status"`
+	LastChecked          time.Time    `json:"lastChecked"`
+	LastNagged           time.Time    `json:"lastNagged"`
+	LastNaggedStatus     UpdateStatus `json:"lastNaggedStatus"`
+	SnoozeUntil          time.Time    `json:"snoozeUntil"`
+}
+
+// staleForChannel reports whether entry was cached under a different update channel than the
+// one now configured, meaning its status can't be trusted until the next fresh check.
+func (entry cacheEntry) staleForChannel(channel Channel) bool {
+	return entry.Channel != channel.String()
+}
+
+// loadCache reads and decodes the cache file at filePath. A missing or corrupt cache file is
+// not an error; it just means we have nothing cached yet.
+func loadCache(filePath string) *cacheEntry {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil
+	}
+	return &entry
+}
+
+// save writes entry to filePath as JSON.
+func (entry cacheEntry) save(filePath string) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filePath, data, 0666)
+}
+
+// snoozed reports whether the user has silenced banners until some point still in the future.
+func (entry cacheEntry) snoozed() bool {
+	return entry.SnoozeUntil.After(time.Now())
+}
+
+// dueToNag reports whether the banner should be shown again: either the severity has changed
+// since the last nag (so an escalation, e.g. UpdateAvailable -> Incompatible, is never silently
+// swallowed by an older, less severe nag's cooldown), or enough time has passed since we last
+// nagged about this exact status.
+func (entry cacheEntry) dueToNag() bool {
+	if entry.Status != entry.LastNaggedStatus {
+		return true
+	}
+	return time.Since(entry.LastNagged) >= checkNagWindow
+}
+
+// latestForChannel returns the manifest field that was the reported "latest version" for the
+// channel the entry was cached under, for display in the banner/update command.
+func (entry cacheEntry) latestForChannel() string {
+	channel, err := ParseChannel(entry.Channel)
+	if err != nil {
+		return entry.LatestStableVersion
+	}
+	latest, err := highestCandidate((manifest{
+		LatestStableVersion:  entry.LatestStableVersion,
+		LatestPreviewVersion: entry.LatestPreviewVersion,
+		LatestNightlyVersion: entry.LatestNightlyVersion,
+	}).entries(), channel)
+	if err != nil || latest == nil {
+		return entry.LatestStableVersion
+	}
+	return latest.String()
+}
@@ -0,0 +1,59 @@
+// Copyright © 2017 Microsoft <wastore@microsoft.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package updater
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDueToNagWithinWindowForSameStatus(t *testing.T) {
+	entry := cacheEntry{
+		Status:           UpdateAvailable,
+		LastNaggedStatus: UpdateAvailable,
+		LastNagged:       time.Now().Add(-time.Hour),
+	}
+	if entry.dueToNag() {
+		t.Errorf("expected dueToNag() to be false within the 24h window for an unchanged status")
+	}
+}
+
+func TestDueToNagOnSeverityEscalation(t *testing.T) {
+	entry := cacheEntry{
+		Status:           Incompatible,
+		LastNaggedStatus: UpdateAvailable,
+		LastNagged:       time.Now().Add(-time.Hour),
+	}
+	if !entry.dueToNag() {
+		t.Errorf("expected dueToNag() to be true when the status escalated, even within the 24h window")
+	}
+}
+
+func TestDueToNagAfterWindowElapses(t *testing.T) {
+	entry := cacheEntry{
+		Status:           UpdateAvailable,
+		LastNaggedStatus: UpdateAvailable,
+		LastNagged:       time.Now().Add(-25 * time.Hour),
+	}
+	if !entry.dueToNag() {
+		t.Errorf("expected dueToNag() to be true once the 24h window has elapsed")
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright © 2017 Microsoft <wastore@microsoft.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package updater
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Channel selects which class of release a user is willing to be told about. It determines
+// which entries of the release manifest are eligible "latest version" candidates.
+type Channel int
+
+const (
+	// ChannelStable only considers fully released versions; pre-releases of any kind are
+	// never reported as an upgrade.
+	ChannelStable Channel = iota
+	// ChannelPreview additionally considers the latest -preview build.
+	ChannelPreview
+	// ChannelNightly additionally considers date-stamped -nightly.YYYYMMDDHHMM builds.
+	ChannelNightly
+)
+
+// String renders the channel the way it is written in the cache file, the --update-channel
+// flag and the AZCOPY_UPDATE_CHANNEL environment variable.
+func (c Channel) String() string {
+	switch c {
+	case ChannelStable:
+		return "stable"
+	case ChannelPreview:
+		return "preview"
+	case ChannelNightly:
+		return "nightly"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseChannel parses raw (case-insensitively) into a Channel.
+func ParseChannel(raw string) (Channel, error) {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "", "stable":
+		return ChannelStable, nil
+	case "preview":
+		return ChannelPreview, nil
+	case "nightly":
+		return ChannelNightly, nil
+	default:
+		return ChannelStable, fmt.Errorf("unrecognized update channel %q (expected stable, preview or nightly)", raw)
+	}
+}
+
+// isPreview reports whether v is a -preview pre-release.
+func (v Version) isPreview() bool {
+	return len(v.preRelease) == 1 && v.preRelease[0] == "preview"
+}
+
+// isNightly reports whether v is a -nightly.YYYYMMDDHHMM pre-release.
+func (v Version) isNightly() bool {
+	return len(v.preRelease) == 2 && v.preRelease[0] == "nightly"
+}
+
+// eligibleFor reports whether v is a candidate "latest version" for channel: stable users never
+// see pre-releases, preview users see stable and preview builds, and nightly users see all three.
+func (v Version) eligibleFor(channel Channel) bool {
+	switch {
+	case !v.isPreRelease():
+		return true
+	case v.isNightly():
+		return channel == ChannelNightly
+	default:
+		return channel == ChannelPreview || channel == ChannelNightly
+	}
+}
+
+// channelRank orders v's own release kind against the other kinds a given channel can offer:
+// a nightly build always outranks a preview build of the same major.minor.patch, which in turn
+// outranks a stable build, reflecting that -nightly and -preview aren't comparable via generic
+// SemVer pre-release precedence (lexical comparison of "nightly" vs "preview" gets this backwards).
+func (v Version) channelRank() int {
+	switch {
+	case v.isNightly():
+		return 2
+	case v.isPreview():
+		return 1
+	default:
+		return 0
+	}
+}
+
+// preferredCandidate returns whichever of a, b should be reported as the "latest version":
+// the one with the higher major.minor.patch wins outright; if those are equal, the one whose
+// release kind ranks higher wins (nightly > preview > stable); only if both the core version
+// and the kind match do we fall back to full SemVer precedence (e.g. to order two nightly
+// builds by their timestamp, or two -rc.N builds by N).
+func preferredCandidate(a, b Version) Version {
+	if c := a.compareCore(b); c != 0 {
+		if c > 0 {
+			return a
+		}
+		return b
+	}
+
+	if a.channelRank() != b.channelRank() {
+		if a.channelRank() > b.channelRank() {
+			return a
+		}
+		return b
+	}
+
+	if a.NewerThan(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,90 @@
+// Copyright © 2017 Microsoft <wastore@microsoft.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package updater
+
+import (
+	"testing"
+)
+
+func TestParseChannel(t *testing.T) {
+	cases := []struct {
+		raw     string
+		want    Channel
+		wantErr bool
+	}{
+		{"", ChannelStable, false},
+		{"stable", ChannelStable, false},
+		{"Preview", ChannelPreview, false},
+		{"NIGHTLY", ChannelNightly, false},
+		{"bogus", ChannelStable, true},
+	}
+	for _, tc := range cases {
+		got, err := ParseChannel(tc.raw)
+		if tc.wantErr != (err != nil) {
+			t.Errorf("ParseChannel(%q) error = %v, wantErr %v", tc.raw, err, tc.wantErr)
+		}
+		if got != tc.want {
+			t.Errorf("ParseChannel(%q) = %v, want %v", tc.raw, got, tc.want)
+		}
+	}
+}
+
+func TestVersionEligibleForChannel(t *testing.T) {
+	mustParse := func(raw string) Version {
+		v, err := NewVersion(raw)
+		if err != nil {
+			t.Fatalf("NewVersion(%q) returned unexpected error: %v", raw, err)
+		}
+		return *v
+	}
+
+	cases := []struct {
+		version string
+		channel Channel
+		want    bool
+	}{
+		{"10.2.0", ChannelStable, true},
+		{"10.3.0-preview", ChannelStable, false},
+		{"10.3.0-preview", ChannelPreview, true},
+		{"10.3.0-nightly.202607261200", ChannelPreview, false},
+		{"10.3.0-nightly.202607261200", ChannelNightly, true},
+		{"10.3.0-preview", ChannelNightly, true},
+	}
+	for _, tc := range cases {
+		if got := mustParse(tc.version).eligibleFor(tc.channel); got != tc.want {
+			t.Errorf("eligibleFor(%q, %v) = %v, want %v", tc.version, tc.channel, got, tc.want)
+		}
+	}
+}
+
+func TestNightlyOrderedByTimestamp(t *testing.T) {
+	older, err := NewVersion("10.3.0-nightly.202601010000")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	newer, err := NewVersion("10.3.0-nightly.202607261200")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !older.OlderThan(*newer) {
+		t.Errorf("expected earlier nightly timestamp to be older")
+	}
+}
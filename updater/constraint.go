@@ -0,0 +1,200 @@
+// Copyright © 2017 Microsoft <wastore@microsoft.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package updater
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+type constraintOp int
+
+const (
+	constraintGTE constraintOp = iota
+	constraintLTE
+	constraintGT
+	constraintLT
+	constraintEQ
+)
+
+type constraintRange struct {
+	op constraintOp
+	v  Version
+}
+
+func (r constraintRange) check(v Version) bool {
+	switch r.op {
+	case constraintGTE:
+		return !v.OlderThan(r.v)
+	case constraintLTE:
+		return !v.NewerThan(r.v)
+	case constraintGT:
+		return v.NewerThan(r.v)
+	case constraintLT:
+		return v.OlderThan(r.v)
+	case constraintEQ:
+		return v.compare(r.v) == 0
+	default:
+		return false
+	}
+}
+
+// Constraint expresses an allowed range of Versions, e.g. a minimum-supported-client window or
+// a deprecation range. It is built from a comma-separated list of comparator expressions
+// (">= 10.2.0, < 11.0.0") or a tilde shorthand ("~10.2", meaning ">= 10.2.0, < 10.3.0").
+// All comparator expressions in a Constraint must hold for Check to return true.
+type Constraint struct {
+	ranges   []constraintRange
+	original string
+}
+
+const standardConstraintError = "invalid version constraint string"
+
+// NewConstraint parses raw into a Constraint, returning an error if raw is not a valid constraint string.
+func NewConstraint(raw string) (*Constraint, error) {
+	c := &Constraint{original: raw}
+
+	for _, clause := range strings.Split(raw, ",") {
+		clause = strings.TrimSpace(clause)
+		if clause == "" {
+			return nil, errors.New(standardConstraintError)
+		}
+
+		if strings.HasPrefix(clause, "~") {
+			ranges, err := parseTildeClause(strings.TrimSpace(clause[1:]))
+			if err != nil {
+				return nil, err
+			}
+			c.ranges = append(c.ranges, ranges...)
+			continue
+		}
+
+		op, rest := parseConstraintOperator(clause)
+		rest = strings.TrimSpace(rest)
+		v, err := NewVersion(rest)
+		if err != nil {
+			return nil, errors.New(standardConstraintError)
+		}
+		c.ranges = append(c.ranges, constraintRange{op: op, v: *v})
+	}
+
+	if len(c.ranges) == 0 {
+		return nil, errors.New(standardConstraintError)
+	}
+
+	return c, nil
+}
+
+// parseConstraintOperator splits a comparator clause like ">= 10.2.0" into its operator
+// (defaulting to an exact match if none is given) and the remaining version string.
+func parseConstraintOperator(clause string) (constraintOp, string) {
+	switch {
+	case strings.HasPrefix(clause, ">="):
+		return constraintGTE, clause[2:]
+	case strings.HasPrefix(clause, "<="):
+		return constraintLTE, clause[2:]
+	case strings.HasPrefix(clause, ">"):
+		return constraintGT, clause[1:]
+	case strings.HasPrefix(clause, "<"):
+		return constraintLT, clause[1:]
+	case strings.HasPrefix(clause, "=="):
+		return constraintEQ, clause[2:]
+	case strings.HasPrefix(clause, "="):
+		return constraintEQ, clause[1:]
+	default:
+		return constraintEQ, clause
+	}
+}
+
+// parseTildeClause expands a tilde shorthand ("~10", "~10.2" or "~10.2.3") into the
+// [>=, <) range it denotes: patch-level (and, for a bare major, minor-level) changes are
+// allowed, but the next minor (or major) version is not.
+func parseTildeClause(core string) ([]constraintRange, error) {
+	segments := strings.Split(core, ".")
+
+	var major, minor, patch int64
+	var err error
+	switch len(segments) {
+	case 1:
+		major, err = parseConstraintSegment(segments[0])
+	case 2:
+		major, err = parseConstraintSegment(segments[0])
+		if err == nil {
+			minor, err = parseConstraintSegment(segments[1])
+		}
+	case 3:
+		major, err = parseConstraintSegment(segments[0])
+		if err == nil {
+			minor, err = parseConstraintSegment(segments[1])
+		}
+		if err == nil {
+			patch, err = parseConstraintSegment(segments[2])
+		}
+	default:
+		return nil, errors.New(standardConstraintError)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	lower := Version{major: major, minor: minor, patch: patch}
+	lower.original = formatCoreVersion(lower)
+
+	var upper Version
+	if len(segments) == 1 {
+		upper = Version{major: major + 1}
+	} else {
+		upper = Version{major: major, minor: minor + 1}
+	}
+	upper.original = formatCoreVersion(upper)
+
+	return []constraintRange{
+		{op: constraintGTE, v: lower},
+		{op: constraintLT, v: upper},
+	}, nil
+}
+
+func parseConstraintSegment(s string) (int64, error) {
+	val, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || val < 0 {
+		return 0, errors.New(standardConstraintError)
+	}
+	return val, nil
+}
+
+func formatCoreVersion(v Version) string {
+	return strings.Join([]string{
+		strconv.FormatInt(v.major, 10),
+		strconv.FormatInt(v.minor, 10),
+		strconv.FormatInt(v.patch, 10),
+	}, ".")
+}
+
+// Check reports whether v satisfies every comparator clause in the Constraint.
+func (c Constraint) Check(v Version) bool {
+	for _, r := range c.ranges {
+		if !r.check(v) {
+			return false
+		}
+	}
+	return true
+}
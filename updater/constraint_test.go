@@ -0,0 +1,85 @@
+// Copyright © 2017 Microsoft <wastore@microsoft.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package updater
+
+import (
+	"testing"
+)
+
+func TestConstraintCheck(t *testing.T) {
+	c, err := NewConstraint(">= 10.2.0, < 11.0.0")
+	if err != nil {
+		t.Fatalf("NewConstraint returned unexpected error: %v", err)
+	}
+
+	mustParse := func(raw string) Version {
+		v, err := NewVersion(raw)
+		if err != nil {
+			t.Fatalf("NewVersion(%q) returned unexpected error: %v", raw, err)
+		}
+		return *v
+	}
+
+	cases := []struct {
+		version string
+		want    bool
+	}{
+		{"10.1.9", false},
+		{"10.2.0", true},
+		{"10.5.3", true},
+		{"11.0.0", false},
+	}
+	for _, tc := range cases {
+		if got := c.Check(mustParse(tc.version)); got != tc.want {
+			t.Errorf("Check(%q) = %v, want %v", tc.version, got, tc.want)
+		}
+	}
+}
+
+func TestConstraintTilde(t *testing.T) {
+	c, err := NewConstraint("~10.2")
+	if err != nil {
+		t.Fatalf("NewConstraint returned unexpected error: %v", err)
+	}
+
+	mustParse := func(raw string) Version {
+		v, err := NewVersion(raw)
+		if err != nil {
+			t.Fatalf("NewVersion(%q) returned unexpected error: %v", raw, err)
+		}
+		return *v
+	}
+
+	cases := []struct {
+		version string
+		want    bool
+	}{
+		{"10.1.9", false},
+		{"10.2.0", true},
+		{"10.2.9", true},
+		{"10.3.0", false},
+	}
+	for _, tc := range cases {
+		if got := c.Check(mustParse(tc.version)); got != tc.want {
+			t.Errorf("Check(%q) = %v, want %v", tc.version, got, tc.want)
+		}
+	}
+}
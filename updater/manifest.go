@@ -0,0 +1,76 @@
+// Copyright © 2017 Microsoft <wastore@microsoft.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package updater
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// defaultManifestURL points at the published JSON manifest describing the current release
+// state of azcopy. It is a var, not a const, so code in this package (such as tests) can point
+// it elsewhere.
+var defaultManifestURL = "https://aka.ms/azcopy/release-manifest"
+
+// manifest is the JSON document azcopy polls to learn about available releases.
+type manifest struct {
+	LatestStableVersion  string `json:"latestStableVersion"`
+	LatestPreviewVersion string `json:"latestPreviewVersion"`
+	LatestNightlyVersion string `json:"latestNightlyVersion"`
+	MinimumVersion       string `json:"minimumVersion"`
+	// DeprecatedConstraint, when set, is a Constraint string (e.g. "<= 10.1.0") identifying the
+	// versions that still run but are past their recommended upgrade window.
+	DeprecatedConstraint string `json:"deprecatedConstraint"`
+	ReleaseNotesURL      string `json:"releaseNotesUrl"`
+}
+
+// entries returns every "latest version" field the manifest carries, unfiltered; callers pick
+// the ones eligible for a given channel via highestCandidate, which consults Version.eligibleFor.
+func (m manifest) entries() []string {
+	return []string{m.LatestStableVersion, m.LatestPreviewVersion, m.LatestNightlyVersion}
+}
+
+// fetchManifest downloads and decodes the release manifest from url.
+func fetchManifest(ctx context.Context, url string) (*manifest, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch release manifest: unexpected status %s", resp.Status)
+	}
+
+	var m manifest
+	if err := json.NewDecoder(resp.Body).Decode(&m); err != nil {
+		return nil, fmt.Errorf("failed to parse release manifest: %w", err)
+	}
+
+	return &m, nil
+}
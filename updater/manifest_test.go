@@ -0,0 +1,57 @@
+// Copyright © 2017 Microsoft <wastore@microsoft.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package updater
+
+import (
+	"testing"
+)
+
+func TestManifestCandidatesForChannel(t *testing.T) {
+	m := manifest{
+		LatestStableVersion:  "10.2.0",
+		LatestPreviewVersion: "10.3.0-preview",
+		LatestNightlyVersion: "10.3.0-nightly.202607261200",
+	}
+
+	stable, err := highestCandidate(m.entries(), ChannelStable)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stable == nil || stable.String() != "10.2.0" {
+		t.Errorf("stable channel should only ever see 10.2.0, got %v", stable)
+	}
+
+	preview, err := highestCandidate(m.entries(), ChannelPreview)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if preview == nil || preview.String() != "10.3.0-preview" {
+		t.Errorf("preview channel should prefer 10.3.0-preview, got %v", preview)
+	}
+
+	nightly, err := highestCandidate(m.entries(), ChannelNightly)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if nightly == nil || nightly.String() != "10.3.0-nightly.202607261200" {
+		t.Errorf("nightly channel should prefer the nightly build, got %v", nightly)
+	}
+}
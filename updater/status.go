@@ -0,0 +1,87 @@
+// Copyright © 2017 Microsoft <wastore@microsoft.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package updater
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// UpdateStatus summarizes where the running build stands relative to the latest manifest
+// known to azcopy.
+type UpdateStatus int
+
+const (
+	// UpToDate means the running build is at or ahead of the latest version for its channel.
+	UpToDate UpdateStatus = iota
+	// UpdateAvailable means a newer version exists but the running build is still supported.
+	UpdateAvailable
+	// Deprecated means the running build still works but is past the point where we
+	// recommend upgrading; CI/scripts can key off this to fail a pipeline early.
+	Deprecated
+	// Incompatible means the running build is older than the minimum supported version and
+	// jobs should refuse to start without an explicit override.
+	Incompatible
+)
+
+// String renders the status the way it should appear in banners and `azcopy update` output.
+func (s UpdateStatus) String() string {
+	switch s {
+	case UpToDate:
+		return "UpToDate"
+	case UpdateAvailable:
+		return "UpdateAvailable"
+	case Deprecated:
+		return "Deprecated"
+	case Incompatible:
+		return "Incompatible"
+	default:
+		return "Unknown"
+	}
+}
+
+// MarshalJSON renders the status as its string name, so that `azcopy update --json` output and
+// the on-disk cache stay readable and stable across future iota reorderings.
+func (s UpdateStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// UnmarshalJSON parses a status previously rendered by MarshalJSON.
+func (s *UpdateStatus) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+
+	switch name {
+	case "UpToDate":
+		*s = UpToDate
+	case "UpdateAvailable":
+		*s = UpdateAvailable
+	case "Deprecated":
+		*s = Deprecated
+	case "Incompatible":
+		*s = Incompatible
+	default:
+		return fmt.Errorf("unrecognized update status %q", name)
+	}
+	return nil
+}
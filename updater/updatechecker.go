@@ -0,0 +1,260 @@
+// Copyright © 2017 Microsoft <wastore@microsoft.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package updater
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultPollInterval is how often the background poll started by StartBackgroundPoll refreshes
+// its view of the release manifest when given a non-positive interval. azcopy's commands always
+// poll at this interval; no flag or environment variable changes it.
+const DefaultPollInterval = 24 * time.Hour
+
+// UpdateChecker tracks azcopy's update status against a published release manifest, caching
+// the result on disk so a status is known before an invocation's own manifest fetch completes
+// and the user isn't nagged about the same status more than once per day. It is safe for concurrent use: the background poll started by
+// StartBackgroundPoll and the foreground banner/command code path both read and write it.
+type UpdateChecker struct {
+	mu sync.Mutex
+
+	currentVersion Version
+	channel        Channel
+	manifestURL    string
+	cacheFilePath  string
+
+	status          UpdateStatus
+	lastChecked     time.Time
+	releaseNotesURL string
+}
+
+// NewUpdateChecker creates an UpdateChecker for currentVersion on the given channel,
+// persisting its results to cacheFilePath. If a fresh cache entry already exists on disk for
+// the same channel, it is loaded immediately so Status/ReleaseNotesURL are meaningful before
+// the first network check completes; a cache entry written under a different channel is
+// treated as stale, since switching channels can change which version counts as "latest".
+func NewUpdateChecker(currentVersion Version, channel Channel, cacheFilePath string) *UpdateChecker {
+	c := &UpdateChecker{
+		currentVersion: currentVersion,
+		channel:        channel,
+		manifestURL:    defaultManifestURL,
+		cacheFilePath:  cacheFilePath,
+	}
+
+	if entry := loadCache(cacheFilePath); entry != nil && !entry.staleForChannel(channel) {
+		c.status = entry.Status
+		c.lastChecked = entry.LastChecked
+		c.releaseNotesURL = entry.ReleaseNotesURL
+	}
+
+	return c
+}
+
+// Status returns the most recently computed UpdateStatus.
+func (c *UpdateChecker) Status() UpdateStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.status
+}
+
+// LastChecked returns the time of the last successful manifest fetch.
+func (c *UpdateChecker) LastChecked() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lastChecked
+}
+
+// ReleaseNotesURL returns the release-notes link from the last successful manifest fetch.
+func (c *UpdateChecker) ReleaseNotesURL() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.releaseNotesURL
+}
+
+// Check fetches the release manifest, compares it against the running version, persists the
+// result to the cache file and returns the resulting status.
+func (c *UpdateChecker) Check(ctx context.Context) (UpdateStatus, error) {
+	m, err := fetchManifest(ctx, c.manifestURL)
+	if err != nil {
+		return UpToDate, err
+	}
+
+	status, err := c.resolveStatus(m)
+	if err != nil {
+		return UpToDate, err
+	}
+
+	c.mu.Lock()
+	c.status = status
+	c.lastChecked = time.Now()
+	c.releaseNotesURL = m.ReleaseNotesURL
+	c.mu.Unlock()
+
+	entry := loadCache(c.cacheFilePath)
+	if entry == nil {
+		entry = &cacheEntry{}
+	} else if entry.staleForChannel(c.channel) {
+		// a channel switch invalidates the cached answer, but not the user's snooze
+		entry = &cacheEntry{SnoozeUntil: entry.SnoozeUntil}
+	}
+	entry.LatestStableVersion = m.LatestStableVersion
+	entry.LatestPreviewVersion = m.LatestPreviewVersion
+	entry.LatestNightlyVersion = m.LatestNightlyVersion
+	entry.MinimumVersion = m.MinimumVersion
+	entry.ReleaseNotesURL = m.ReleaseNotesURL
+	entry.Channel = c.channel.String()
+	entry.Status = status
+	entry.LastChecked = time.Now()
+	return status, entry.save(c.cacheFilePath)
+}
+
+// resolveStatus compares the running version against a freshly fetched manifest.
+func (c *UpdateChecker) resolveStatus(m *manifest) (UpdateStatus, error) {
+	if m.MinimumVersion != "" {
+		minimum, err := NewVersion(m.MinimumVersion)
+		if err != nil {
+			return UpToDate, fmt.Errorf("manifest has invalid minimumVersion: %w", err)
+		}
+		if c.currentVersion.OlderThan(*minimum) {
+			return Incompatible, nil
+		}
+	}
+
+	if m.DeprecatedConstraint != "" {
+		deprecated, err := NewConstraint(m.DeprecatedConstraint)
+		if err != nil {
+			return UpToDate, fmt.Errorf("manifest has invalid deprecatedConstraint: %w", err)
+		}
+		if deprecated.Check(c.currentVersion) {
+			return Deprecated, nil
+		}
+	}
+
+	latest, err := highestCandidate(m.entries(), c.channel)
+	if err != nil {
+		return UpToDate, err
+	}
+	if latest == nil {
+		return UpToDate, nil
+	}
+
+	if c.currentVersion.OlderThan(*latest) {
+		return UpdateAvailable, nil
+	}
+
+	return UpToDate, nil
+}
+
+// StartBackgroundPoll launches a goroutine that checks for updates once immediately and then
+// once per interval, stopping when ctx is cancelled. It never blocks the caller and swallows
+// transient network errors; a poll failure just means the cached status keeps being used until
+// the next tick succeeds.
+func (c *UpdateChecker) StartBackgroundPoll(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+
+	go func() {
+		c.pollOnce(ctx)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				c.pollOnce(ctx)
+			}
+		}
+	}()
+}
+
+func (c *UpdateChecker) pollOnce(ctx context.Context) {
+	_, _ = c.Check(ctx)
+}
+
+// highestCandidate parses the non-empty raw version strings, keeps only the ones eligible for
+// channel (see Version.eligibleFor), and returns whichever one should be reported as the "latest
+// version" (see preferredCandidate), or nil if nothing eligible was published right now.
+func highestCandidate(raw []string, channel Channel) (*Version, error) {
+	var highest *Version
+	for _, s := range raw {
+		if s == "" {
+			continue
+		}
+		v, err := NewVersion(s)
+		if err != nil {
+			return nil, fmt.Errorf("manifest has invalid version %q: %w", s, err)
+		}
+		if !v.eligibleFor(channel) {
+			continue
+		}
+		if highest == nil {
+			highest = v
+			continue
+		}
+		preferred := preferredCandidate(*highest, *v)
+		highest = &preferred
+	}
+	return highest, nil
+}
+
+// Snooze silences banner nagging until duration from now, regardless of status.
+func (c *UpdateChecker) Snooze(duration time.Duration) error {
+	entry := loadCache(c.cacheFilePath)
+	if entry == nil {
+		entry = &cacheEntry{}
+	}
+	entry.SnoozeUntil = time.Now().Add(duration)
+	return entry.save(c.cacheFilePath)
+}
+
+// Banner returns the one-line message to print at the end of jobs list/copy/sync output, and
+// whether it is due to be shown: nothing is due if the user is up to date, snoozed, or was
+// already shown this severity within the last 24h.
+func (c *UpdateChecker) Banner() (string, bool) {
+	entry := loadCache(c.cacheFilePath)
+	if entry == nil || entry.staleForChannel(c.channel) || entry.Status == UpToDate {
+		return "", false
+	}
+	if entry.snoozed() || !entry.dueToNag() {
+		return "", false
+	}
+
+	entry.LastNagged = time.Now()
+	entry.LastNaggedStatus = entry.Status
+	_ = entry.save(c.cacheFilePath)
+
+	switch entry.Status {
+	case UpdateAvailable:
+		return fmt.Sprintf("A newer version of azcopy (%s) is available. See %s for details.", entry.latestForChannel(), entry.ReleaseNotesURL), true
+	case Deprecated:
+		return fmt.Sprintf("This version of azcopy is deprecated. Please upgrade to %s. See %s for details.", entry.latestForChannel(), entry.ReleaseNotesURL), true
+	case Incompatible:
+		return fmt.Sprintf("This version of azcopy is no longer supported (minimum is %s). Jobs will refuse to start without --force. See %s for details.", entry.MinimumVersion, entry.ReleaseNotesURL), true
+	default:
+		return "", false
+	}
+}
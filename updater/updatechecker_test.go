@@ -0,0 +1,93 @@
+// Copyright © 2017 Microsoft <wastore@microsoft.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package updater
+
+import (
+	"testing"
+)
+
+func newTestChecker(t *testing.T, currentVersion string, channel Channel) *UpdateChecker {
+	t.Helper()
+	v, err := NewVersion(currentVersion)
+	if err != nil {
+		t.Fatalf("NewVersion(%q) returned unexpected error: %v", currentVersion, err)
+	}
+	return &UpdateChecker{currentVersion: *v, channel: channel}
+}
+
+func TestResolveStatusDeprecated(t *testing.T) {
+	c := newTestChecker(t, "10.1.0", ChannelStable)
+
+	status, err := c.resolveStatus(&manifest{
+		LatestStableVersion:  "10.1.0",
+		DeprecatedConstraint: "<= 10.1.0",
+	})
+	if err != nil {
+		t.Fatalf("resolveStatus returned unexpected error: %v", err)
+	}
+	if status != Deprecated {
+		t.Errorf("resolveStatus() = %v, want %v", status, Deprecated)
+	}
+}
+
+func TestResolveStatusIncompatibleBeatsDeprecated(t *testing.T) {
+	c := newTestChecker(t, "9.0.0", ChannelStable)
+
+	status, err := c.resolveStatus(&manifest{
+		LatestStableVersion:  "10.1.0",
+		MinimumVersion:       "10.0.0",
+		DeprecatedConstraint: "<= 10.1.0",
+	})
+	if err != nil {
+		t.Fatalf("resolveStatus returned unexpected error: %v", err)
+	}
+	if status != Incompatible {
+		t.Errorf("resolveStatus() = %v, want %v", status, Incompatible)
+	}
+}
+
+func TestResolveStatusUpdateAvailable(t *testing.T) {
+	c := newTestChecker(t, "10.1.0", ChannelStable)
+
+	status, err := c.resolveStatus(&manifest{
+		LatestStableVersion: "10.2.0",
+	})
+	if err != nil {
+		t.Fatalf("resolveStatus returned unexpected error: %v", err)
+	}
+	if status != UpdateAvailable {
+		t.Errorf("resolveStatus() = %v, want %v", status, UpdateAvailable)
+	}
+}
+
+func TestResolveStatusUpToDate(t *testing.T) {
+	c := newTestChecker(t, "10.2.0", ChannelStable)
+
+	status, err := c.resolveStatus(&manifest{
+		LatestStableVersion: "10.2.0",
+	})
+	if err != nil {
+		t.Fatalf("resolveStatus returned unexpected error: %v", err)
+	}
+	if status != UpToDate {
+		t.Errorf("resolveStatus() = %v, want %v", status, UpToDate)
+	}
+}
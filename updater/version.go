@@ -0,0 +1,255 @@
+// Copyright © 2017 Microsoft <wastore@microsoft.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package updater implements azcopy's background update-notification subsystem: parsing and
+// comparing the tool's own SemVer version, polling a remote manifest for newer releases, and
+// caching the result so we only ever nag the user once per rate-limit window.
+package updater
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// versionFileTimeFormat is the time layout used when persisting cache expiries to disk.
+const versionFileTimeFormat = "2006-01-02T15:04:05Z07:00"
+
+// Version represents a SemVer 2.0 (https://semver.org) version string: major.minor.patch,
+// optionally followed by a dot-separated pre-release identifier list (-alpha, -beta.2, -rc.1)
+// and/or build metadata (+build.45). Build metadata is kept only for display; it never
+// participates in comparison.
+// Examples: 10.1.0, 11.2.0-preview, 10.1.0-beta.2, 10.1.0-rc.1+build.45
+type Version struct {
+	major, minor, patch int64
+	preRelease          []string
+	build               string
+	original            string
+}
+
+const standardVersionError = "invalid version string"
+
+// NewVersion parses raw into a Version, returning an error if raw is not a valid SemVer 2.0 string.
+func NewVersion(raw string) (*Version, error) {
+	v := &Version{original: raw}
+
+	rest := raw
+	if idx := strings.Index(rest, "+"); idx != -1 {
+		v.build = rest[idx+1:]
+		rest = rest[:idx]
+		if v.build == "" {
+			return nil, errors.New(standardVersionError)
+		}
+	}
+
+	core := rest
+	if idx := strings.Index(rest, "-"); idx != -1 {
+		core = rest[:idx]
+		preRelease := rest[idx+1:]
+		if preRelease == "" {
+			return nil, errors.New(standardVersionError)
+		}
+		v.preRelease = strings.Split(preRelease, ".")
+		for _, id := range v.preRelease {
+			if id == "" {
+				return nil, errors.New(standardVersionError)
+			}
+		}
+	}
+
+	major, minor, patch, err := parseVersionCore(core)
+	if err != nil {
+		return nil, err
+	}
+	v.major, v.minor, v.patch = major, minor, patch
+
+	return v, nil
+}
+
+// parseVersionCore parses a "major.minor.patch" string into its three numeric segments.
+func parseVersionCore(core string) (major, minor, patch int64, err error) {
+	segments := strings.Split(core, ".")
+	if len(segments) != 3 {
+		return 0, 0, 0, errors.New(standardVersionError)
+	}
+
+	nums := make([]int64, 3)
+	for i, segment := range segments {
+		val, err := strconv.ParseInt(segment, 10, 64)
+		if err != nil || val < 0 {
+			return 0, 0, 0, errors.New(standardVersionError)
+		}
+		nums[i] = val
+	}
+
+	return nums[0], nums[1], nums[2], nil
+}
+
+// isPreRelease returns true if v is a pre-release version (has a -identifier suffix).
+func (v Version) isPreRelease() bool {
+	return len(v.preRelease) > 0
+}
+
+// compareCore compares only the major/minor/patch numbers, ignoring pre-release and build.
+func (v Version) compareCore(v2 Version) int {
+	if c := compareInt64(v.major, v2.major); c != 0 {
+		return c
+	}
+	if c := compareInt64(v.minor, v2.minor); c != 0 {
+		return c
+	}
+	return compareInt64(v.patch, v2.patch)
+}
+
+// compare this version (v) to another version (v2)
+// return -1 if v is smaller/older than v2
+// return 0 if v is equal to v2
+// return 1 if v is bigger/newer than v2
+func (v Version) compare(v2 Version) int {
+	// short-circuit if the two version have the exact same raw string, no need to compare
+	if v.original == v2.original {
+		return 0
+	}
+
+	// compare the major/minor/patch version; if v has a bigger number, it is newer
+	if c := v.compareCore(v2); c != 0 {
+		return c
+	}
+
+	// major.minor.patch are equal; a pre-release version has lower precedence than the
+	// associated normal version
+	if v.isPreRelease() && !v2.isPreRelease() {
+		return -1
+	}
+	if !v.isPreRelease() && v2.isPreRelease() {
+		return 1
+	}
+	if !v.isPreRelease() && !v2.isPreRelease() {
+		return 0
+	}
+
+	// both are pre-releases: walk the dot-separated identifiers left-to-right
+	return comparePreRelease(v.preRelease, v2.preRelease)
+}
+
+// comparePreRelease compares two pre-release identifier lists per the SemVer 2.0 precedence
+// rules: numeric identifiers compare numerically, alphanumeric identifiers compare lexically,
+// numeric identifiers always have lower precedence than alphanumeric ones, and a larger set of
+// fields has higher precedence than a smaller set if all preceding identifiers are equal.
+func comparePreRelease(a, b []string) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] == b[i] {
+			continue
+		}
+
+		aNum, aIsNum := parseNumericIdentifier(a[i])
+		bNum, bIsNum := parseNumericIdentifier(b[i])
+
+		switch {
+		case aIsNum && bIsNum:
+			return compareInt64(aNum, bNum)
+		case aIsNum && !bIsNum:
+			return -1
+		case !aIsNum && bIsNum:
+			return 1
+		default:
+			if a[i] < b[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	return compareInt64(int64(len(a)), int64(len(b)))
+}
+
+// parseNumericIdentifier reports whether id is made up entirely of digits, and its value if so.
+func parseNumericIdentifier(id string) (int64, bool) {
+	for _, r := range id {
+		if r < '0' || r > '9' {
+			return 0, false
+		}
+	}
+	val, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return val, true
+}
+
+func compareInt64(a, b int64) int {
+	switch {
+	case a > b:
+		return 1
+	case a < b:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// OlderThan detect if version v is older than v2
+func (v Version) OlderThan(v2 Version) bool {
+	return v.compare(v2) == -1
+}
+
+// NewerThan detect if version v is newer than v2
+func (v Version) NewerThan(v2 Version) bool {
+	return v.compare(v2) == 1
+}
+
+// String returns the original, as-parsed version string.
+func (v Version) String() string {
+	return v.original
+}
+
+// CacheNewerVersion caches the version v2 to filePath if v2 is newer than v1
+func (v Version) CacheNewerVersion(v2 Version, filePath string) {
+	if v.OlderThan(v2) {
+		expiry := time.Now().Add(24 * time.Hour).Format(versionFileTimeFormat)
+		if err := os.WriteFile(filePath, []byte(v2.original+","+expiry), 0666); err != nil {
+			fmt.Println(err)
+		}
+	}
+}
+
+// ValidateCachedVersion checks if the given filepath contains cached version, expiry or not.
+// If yes, then it reads the cache, checks if the cache is still fresh and finally creates Version object from it and returns it.
+func ValidateCachedVersion(filePath string) (*Version, error) {
+	// Check the locally cached file to get the version.
+	data, err := os.ReadFile(filePath)
+	if err == nil {
+		// If the data is fresh, don't make the call and return right away
+		versionAndExpiry := strings.Split(fmt.Sprintf("%s", data), ",")
+		if len(versionAndExpiry) == 2 {
+			version, err := NewVersion(versionAndExpiry[0])
+			if err == nil {
+				expiry, err := time.Parse(versionFileTimeFormat, versionAndExpiry[1])
+				if err == nil && expiry.After(time.Now()) {
+					return version, nil
+				}
+			}
+		}
+	}
+	return nil, errors.New("failed to fetch or validate the cached version")
+}
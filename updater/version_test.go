@@ -0,0 +1,102 @@
+// Copyright © 2017 Microsoft <wastore@microsoft.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package updater
+
+import (
+	"testing"
+)
+
+func TestNewVersionParsesSemVer(t *testing.T) {
+	cases := []string{
+		"10.1.0",
+		"11.2.0-preview",
+		"10.1.0-beta.2",
+		"10.1.0-rc.1+build.45",
+		"10.1.0+build.45",
+	}
+	for _, raw := range cases {
+		if _, err := NewVersion(raw); err != nil {
+			t.Errorf("NewVersion(%q) returned unexpected error: %v", raw, err)
+		}
+	}
+}
+
+func TestNewVersionRejectsInvalid(t *testing.T) {
+	cases := []string{
+		"10.1",
+		"10.1.0.1",
+		"10.1.x",
+		"10.1.0-",
+		"10.1.0+",
+	}
+	for _, raw := range cases {
+		if _, err := NewVersion(raw); err == nil {
+			t.Errorf("NewVersion(%q) expected an error, got none", raw)
+		}
+	}
+}
+
+func TestVersionComparePreRelease(t *testing.T) {
+	mustParse := func(raw string) Version {
+		v, err := NewVersion(raw)
+		if err != nil {
+			t.Fatalf("NewVersion(%q) returned unexpected error: %v", raw, err)
+		}
+		return *v
+	}
+
+	// ordered from oldest to newest, per the semver.org precedence example
+	ordered := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+	}
+
+	for i := 0; i < len(ordered)-1; i++ {
+		older := mustParse(ordered[i])
+		newer := mustParse(ordered[i+1])
+		if !older.OlderThan(newer) {
+			t.Errorf("expected %q to be older than %q", ordered[i], ordered[i+1])
+		}
+		if !newer.NewerThan(older) {
+			t.Errorf("expected %q to be newer than %q", ordered[i+1], ordered[i])
+		}
+	}
+}
+
+func TestVersionCompareIgnoresBuildMetadata(t *testing.T) {
+	v1, err := NewVersion("10.1.0-rc.1+build.45")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	v2, err := NewVersion("10.1.0-rc.1+build.99")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v1.compare(*v2) != 0 {
+		t.Errorf("expected versions differing only in build metadata to be equal")
+	}
+}